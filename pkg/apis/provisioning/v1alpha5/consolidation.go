@@ -0,0 +1,31 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha5
+
+// InstanceTypeFamilyLabelKey is set by the cloud provider on every node Karpenter launches, identifying the
+// instance type family (e.g. "m5", "c6g") it belongs to. The even-spread consolidator groups nodes by this label
+// alongside ProvisionerNameLabelKey, since allocatable capacity isn't directly comparable across families.
+const InstanceTypeFamilyLabelKey = Group + "/instance-type-family"
+
+// ConsolidationPolicy configures the even-spread rebalancing pass for nodes launched by a Provisioner. It's
+// additive to (and independent of) ordinary scale-down consolidation: it never empties a node, it only reduces
+// pod-count skew between nodes that are all staying.
+type ConsolidationPolicy struct {
+	// Threshold is the maximum tolerated difference between the most- and least-loaded node's pod count before a
+	// rebalance is proposed. A zero value uses the consolidator's built-in default.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	Threshold int `json:"threshold,omitempty"`
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha5
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProvisionerNameLabelKey is set by Karpenter on every node it launches, identifying the Provisioner responsible
+// for it.
+const ProvisionerNameLabelKey = Group + "/provisioner-name"
+
+// ResourceLimits constrains the total amount of resources Karpenter is allowed to provision against a Provisioner.
+type ResourceLimits struct {
+	// Resources is the map of resource names (e.g. cpu, memory) to the maximum quantity Karpenter may provision
+	// for this Provisioner across every node it launches.
+	// +optional
+	Resources v1.ResourceList `json:"resources,omitempty"`
+}
+
+// ProvisionerSpec describes the constraints a Provisioner places on the nodes it's allowed to launch.
+type ProvisionerSpec struct {
+	// Limits caps the total resources this Provisioner may provision. A nil value means unlimited.
+	// +optional
+	Limits *ResourceLimits `json:"limits,omitempty"`
+	// ConsolidationPolicy enables the even-spread rebalancing pass for nodes launched by this Provisioner. A nil
+	// value disables it.
+	// +optional
+	ConsolidationPolicy *ConsolidationPolicy `json:"consolidationPolicy,omitempty"`
+	// SchedulerProfile names the framework.Profile this Provisioner's nodes are scheduled with. An empty value
+	// uses framework.DefaultProfile, preserving Karpenter's historical, non-pluggable behavior.
+	// +optional
+	SchedulerProfile SchedulerProfileName `json:"schedulerProfile,omitempty"`
+}
+
+// ProvisionerStatus tracks the observed state of a Provisioner.
+type ProvisionerStatus struct {
+	// LastScaleTime is the last time this Provisioner launched or deleted a node.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=provisioners,scope=Cluster,categories=karpenter
+// +kubebuilder:subresource:status
+
+// Provisioner is the Schema for the Provisioners API.
+type Provisioner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProvisionerSpec   `json:"spec,omitempty"`
+	Status ProvisionerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProvisionerList contains a list of Provisioner.
+type ProvisionerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Provisioner `json:"items"`
+}
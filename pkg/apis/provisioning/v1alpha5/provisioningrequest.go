@@ -0,0 +1,101 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha5
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProvisioningRequestPhase describes where a ProvisioningRequest is in its lifecycle.
+type ProvisioningRequestPhase string
+
+const (
+	// ProvisioningRequestPhasePending means the request hasn't been attempted yet, or is waiting on backoff
+	// before its next retry.
+	ProvisioningRequestPhasePending ProvisioningRequestPhase = "Pending"
+	// ProvisioningRequestPhaseProvisioned means every pod in the request scheduled and the backing nodes were
+	// launched.
+	ProvisioningRequestPhaseProvisioned ProvisioningRequestPhase = "Provisioned"
+	// ProvisioningRequestPhaseFailed means the request could not be satisfied as a whole and no nodes were
+	// created on its behalf.
+	ProvisioningRequestPhaseFailed ProvisioningRequestPhase = "Failed"
+)
+
+// ProvisioningRequestSpec describes a bounded batch of pods that must be scheduled with all-or-nothing semantics.
+type ProvisioningRequestSpec struct {
+	// PodTemplates is the bounded set of pods that make up this request. Karpenter either provisions enough
+	// capacity to place every pod in PodTemplates, or fails the request without launching any nodes.
+	// +kubebuilder:validation:MinItems:=1
+	PodTemplates []v1.PodTemplateSpec `json:"podTemplates"`
+	// ProvisionerName is the provisioner whose constraints and limits apply to nodes launched for this request.
+	ProvisionerName string `json:"provisionerName"`
+	// TTLSecondsAfterFinished is how long a Provisioned or Failed request is kept around before being garbage
+	// collected. If unset, the request is kept indefinitely.
+	// +optional
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ProvisioningRequestStatus tracks the outcome of attempting to satisfy a ProvisioningRequest.
+type ProvisioningRequestStatus struct {
+	// Phase is the current lifecycle phase of the request.
+	// +optional
+	Phase ProvisioningRequestPhase `json:"phase,omitempty"`
+	// LastTransitionTime is the last time Phase changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a short, machine-readable explanation for the current phase, primarily set on Failed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// RetryCount is the number of times this request has been attempted and failed.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=provisioningrequests,scope=Cluster,categories=karpenter
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// ProvisioningRequest is a request to atomically provision capacity for a bounded batch of pods, used for
+// gang-scheduled workloads (e.g. ML training, HPC) where partial placement is worse than no placement.
+type ProvisioningRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProvisioningRequestSpec   `json:"spec,omitempty"`
+	Status ProvisioningRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProvisioningRequestList contains a list of ProvisioningRequest.
+type ProvisioningRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProvisioningRequest `json:"items"`
+}
+
+// IsExpired returns true if the request has been Provisioned or Failed for longer than its TTL.
+func (p *ProvisioningRequest) IsExpired(now metav1.Time) bool {
+	if p.Spec.TTLSecondsAfterFinished == nil || p.Status.LastTransitionTime == nil {
+		return false
+	}
+	if p.Status.Phase != ProvisioningRequestPhaseProvisioned && p.Status.Phase != ProvisioningRequestPhaseFailed {
+		return false
+	}
+	return now.Sub(p.Status.LastTransitionTime.Time).Seconds() > float64(*p.Spec.TTLSecondsAfterFinished)
+}
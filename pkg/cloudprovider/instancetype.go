@@ -0,0 +1,32 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cloudprovider defines the interface Karpenter uses to discover instance types and launch nodes against
+// a specific cloud.
+package cloudprovider
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// InstanceType describes a launchable instance type: its name, the resources it offers, and its price.
+type InstanceType interface {
+	// Name is the cloud provider's identifier for this instance type, e.g. "m5.large".
+	Name() string
+	// Resources is the total allocatable resources of a node running this instance type.
+	Resources() v1.ResourceList
+	// Price is the on-demand (or, for spot, expected) hourly price of this instance type, used to sort candidates
+	// from cheapest to most expensive.
+	Price() float64
+}
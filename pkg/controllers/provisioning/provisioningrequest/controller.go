@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provisioningrequest reconciles ProvisioningRequest resources, giving users an all-or-nothing ("gang")
+// provisioning primitive on top of the scheduler's normal best-effort per-pod loop.
+package provisioningrequest
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"knative.dev/pkg/logging"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
+)
+
+const controllerName = "provisioningrequest"
+
+// Controller reconciles ProvisioningRequest objects: it filters out requests that can't currently be attempted,
+// asks the scheduler to solve the remaining ones as an atomic batch, and records the outcome on the request's
+// status so callers can observe Provisioned/Failed/Pending without having to watch Node objects themselves.
+type Controller struct {
+	kubeClient  client.Client
+	provisioner provisioner
+	filter      ProvisioningRequestPodsFilter
+	rateLimiter workqueue.RateLimiter
+}
+
+// provisioner is the subset of *provisioning.Provisioner this controller depends on, kept narrow so it's easy to
+// fake in isolation from the rest of the provisioning stack.
+type provisioner interface {
+	LaunchForRequest(ctx context.Context, requestKey string, pods []*v1.Pod) error
+}
+
+// NewController constructs a ProvisioningRequest controller.
+func NewController(kubeClient client.Client, p provisioner) *Controller {
+	return &Controller{
+		kubeClient:  kubeClient,
+		provisioner: p,
+		filter:      ProvisioningRequestPodsFilter{},
+		// default controller-runtime exponential backoff: retries on failure without hot-looping a request that
+		// can't currently be satisfied.
+		rateLimiter: workqueue.DefaultControllerRateLimiter(),
+	}
+}
+
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).Named(controllerName).With("provisioningrequest", req.Name))
+	pr := &v1alpha5.ProvisioningRequest{}
+	if err := c.kubeClient.Get(ctx, req.NamespacedName, pr); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if pr.IsExpired(metav1.Now()) {
+		return reconcile.Result{}, client.IgnoreNotFound(c.kubeClient.Delete(ctx, pr))
+	}
+	// a request that's already reached a terminal phase, or has nothing to schedule, doesn't need to be
+	// re-attempted; it'll be cleaned up by its TTL (if any) or by the user.
+	if len(c.filter.Filter([]*v1alpha5.ProvisioningRequest{pr})) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	pods := c.podsFor(pr)
+	if err := c.provisioner.LaunchForRequest(ctx, req.String(), pods); err != nil {
+		logging.FromContext(ctx).Errorf("provisioning request failed, %s", err)
+		return c.updateStatus(ctx, pr, v1alpha5.ProvisioningRequestPhaseFailed, err.Error())
+	}
+	return c.updateStatus(ctx, pr, v1alpha5.ProvisioningRequestPhaseProvisioned, "")
+}
+
+// podsFor materializes the request's pod templates into pods named after the request so they can be correlated
+// back to it by the scheduler and by events.
+func (c *Controller) podsFor(pr *v1alpha5.ProvisioningRequest) []*v1.Pod {
+	pods := make([]*v1.Pod, 0, len(pr.Spec.PodTemplates))
+	for i, template := range pr.Spec.PodTemplates {
+		pod := &v1.Pod{
+			ObjectMeta: template.ObjectMeta,
+			Spec:       template.Spec,
+		}
+		pod.Name = fmt.Sprintf("%s-%d", pr.Name, i)
+		pod.Namespace = pr.Namespace
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// updateStatus persists the outcome of this reconciliation, applying the rate limiter's backoff on failure so a
+// request that can't currently be satisfied doesn't get re-attempted in a hot loop.
+func (c *Controller) updateStatus(ctx context.Context, pr *v1alpha5.ProvisioningRequest, phase v1alpha5.ProvisioningRequestPhase, reason string) (reconcile.Result, error) {
+	now := metav1.Now()
+	pr.Status.Phase = phase
+	pr.Status.LastTransitionTime = &now
+	pr.Status.Reason = reason
+	if phase == v1alpha5.ProvisioningRequestPhaseFailed {
+		pr.Status.RetryCount++
+	}
+	if err := c.kubeClient.Status().Update(ctx, pr); err != nil {
+		return reconcile.Result{}, err
+	}
+	if phase == v1alpha5.ProvisioningRequestPhaseFailed {
+		return reconcile.Result{RequeueAfter: c.rateLimiter.When(client.ObjectKeyFromObject(pr))}, nil
+	}
+	c.rateLimiter.Forget(client.ObjectKeyFromObject(pr))
+	return reconcile.Result{}, nil
+}
+
+func (c *Controller) Register(ctx context.Context, m manager.Manager) error {
+	return controllerruntime.
+		NewControllerManagedBy(m).
+		Named(controllerName).
+		For(&v1alpha5.ProvisioningRequest{}).
+		Complete(c)
+}
@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioningrequest
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
+)
+
+// ProvisioningRequestPodsFilter narrows a list of ProvisioningRequests down to the ones that are actually worth
+// handing to the scheduler this reconciliation: requests that have already reached a terminal phase, expired past
+// their TTL, or have an empty pod list don't need a Solve attempt.
+type ProvisioningRequestPodsFilter struct{}
+
+// Filter returns the subset of requests that are still Pending and unexpired.
+func (ProvisioningRequestPodsFilter) Filter(requests []*v1alpha5.ProvisioningRequest) []*v1alpha5.ProvisioningRequest {
+	now := metav1.Now()
+	var filtered []*v1alpha5.ProvisioningRequest
+	for _, pr := range requests {
+		if pr.IsExpired(now) {
+			continue
+		}
+		if pr.Status.Phase == v1alpha5.ProvisioningRequestPhaseProvisioned || pr.Status.Phase == v1alpha5.ProvisioningRequestPhaseFailed {
+			continue
+		}
+		if len(pr.Spec.PodTemplates) == 0 {
+			continue
+		}
+		filtered = append(filtered, pr)
+	}
+	return filtered
+}
@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioningrequest
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
+)
+
+func requestNamed(name string) *v1alpha5.ProvisioningRequest {
+	return &v1alpha5.ProvisioningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha5.ProvisioningRequestSpec{PodTemplates: []v1.PodTemplateSpec{{}}},
+	}
+}
+
+func TestFilterDropsTerminalPhases(t *testing.T) {
+	provisioned := requestNamed("provisioned")
+	provisioned.Status.Phase = v1alpha5.ProvisioningRequestPhaseProvisioned
+	failed := requestNamed("failed")
+	failed.Status.Phase = v1alpha5.ProvisioningRequestPhaseFailed
+	pending := requestNamed("pending")
+	pending.Status.Phase = v1alpha5.ProvisioningRequestPhasePending
+
+	got := (ProvisioningRequestPodsFilter{}).Filter([]*v1alpha5.ProvisioningRequest{provisioned, failed, pending})
+	if len(got) != 1 || got[0].Name != "pending" {
+		t.Fatalf("expected only [pending] to survive, got %v", names(got))
+	}
+}
+
+func TestFilterDropsExpiredRequests(t *testing.T) {
+	now := metav1.Now()
+	ttl := int64(60)
+	expired := requestNamed("expired")
+	expired.Spec.TTLSecondsAfterFinished = &ttl
+	expired.Status.Phase = v1alpha5.ProvisioningRequestPhaseProvisioned
+	expiredTransition := metav1.NewTime(now.Add(-2 * 60 * 1e9))
+	expired.Status.LastTransitionTime = &expiredTransition
+
+	got := (ProvisioningRequestPodsFilter{}).Filter([]*v1alpha5.ProvisioningRequest{expired})
+	if len(got) != 0 {
+		t.Fatalf("expected the expired request to be dropped, got %v", names(got))
+	}
+}
+
+func TestFilterDropsRequestsWithNoPodTemplates(t *testing.T) {
+	empty := requestNamed("empty")
+	empty.Spec.PodTemplates = nil
+
+	got := (ProvisioningRequestPodsFilter{}).Filter([]*v1alpha5.ProvisioningRequest{empty})
+	if len(got) != 0 {
+		t.Fatalf("expected the request with no pod templates to be dropped, got %v", names(got))
+	}
+}
+
+func names(requests []*v1alpha5.ProvisioningRequest) []string {
+	out := make([]string, len(requests))
+	for i, r := range requests {
+		out[i] = r.Name
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// NodeLauncher turns a Node the scheduler decided to create into real infrastructure: an actual Kubernetes Node
+// object and whatever cloud provider resources back it.
+type NodeLauncher interface {
+	Launch(ctx context.Context, node *Node) error
+}
+
+// RequestLauncher adapts a Scheduler into the provisioningrequest package's narrow provisioner interface, giving
+// ProvisioningRequests atomic, all-or-nothing capacity: it runs a request's pods through SolveBatch rather than
+// the per-pod best-effort Solve loop every other caller uses, and only launches nodes once the whole batch is
+// known to fit.
+type RequestLauncher struct {
+	scheduler *Scheduler
+	launcher  NodeLauncher
+}
+
+// NewRequestLauncher constructs a RequestLauncher.
+func NewRequestLauncher(scheduler *Scheduler, launcher NodeLauncher) *RequestLauncher {
+	return &RequestLauncher{scheduler: scheduler, launcher: launcher}
+}
+
+// LaunchForRequest solves pods as a single atomic batch under requestKey and launches every node the solve
+// decided to create. If any pod in the batch can't be placed, SolveBatch fails the whole attempt and no nodes are
+// launched on the request's behalf.
+func (r *RequestLauncher) LaunchForRequest(ctx context.Context, requestKey string, pods []*v1.Pod) error {
+	nodes, err := r.scheduler.SolveBatch(ctx, requestKey, pods)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := r.launcher.Launch(ctx, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/controllers/state"
+)
+
+// singleNUMANodePolicy is the TopologyManagerPolicy value kubelet reports when a node's CPU manager is configured
+// to require that a pod's exclusive resources (CPU, hugepages, devices) all come from the same NUMA zone.
+const singleNUMANodePolicy = "single-numa-node"
+
+// requiresSingleNUMANode returns true if pod can only be admitted by a kubelet running with
+// TopologyManagerPolicy=single-numa-node, which is the case for Guaranteed-QoS pods requesting whole CPUs and/or
+// devices. This mirrors the kubelet's own admission check closely enough to reject infeasible placements during
+// simulation rather than after the pod is already bound.
+func requiresSingleNUMANode(pod *v1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if cpu, ok := c.Resources.Requests[v1.ResourceCPU]; ok {
+			limit := c.Resources.Limits[v1.ResourceCPU]
+			if cpu.MilliValue()%1000 == 0 && cpu.Cmp(limit) == 0 {
+				return true
+			}
+		}
+		for name := range c.Resources.Requests {
+			if name != v1.ResourceCPU && name != v1.ResourceMemory {
+				// a device or hugepage request under Guaranteed QoS also drives single-NUMA-node admission
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fitsSingleZone returns true if every container's exclusive resource requests can be satisfied out of a single
+// zone's remaining budget. It does not attempt bin-packing across containers of the same pod into different
+// zones, matching the kubelet's own single-numa-node behavior of requiring one zone per pod.
+func fitsSingleZone(pod *v1.Pod, zone state.ZoneResources) bool {
+	remaining := zone.Resources.DeepCopy()
+	for _, c := range pod.Spec.Containers {
+		for name, qty := range c.Resources.Requests {
+			avail, ok := remaining[name]
+			if !ok || avail.Cmp(qty) < 0 {
+				return false
+			}
+			avail.Sub(qty)
+			remaining[name] = avail
+		}
+	}
+	return true
+}
+
+// filterInstanceTypesByNRT narrows instanceTypes down to the ones with at least one zone layout (known via
+// nrtCache) able to satisfy pod's single-NUMA-node requirements. Survivors keep their incoming order; they are not
+// ranked by how closely their zone layout matches the pod's request. Scheduler.add calls this before running the
+// provisioner's Filter/Score chain whenever requiresSingleNUMANode(pod) is true, so NUMA-sensitive pods don't get
+// placed onto an instance type that can't actually satisfy kubelet's admission check once launched.
+func filterInstanceTypesByNRT(pod *v1.Pod, instanceTypes []cloudprovider.InstanceType, nrtCache *state.NRTCache) ([]cloudprovider.InstanceType, error) {
+	var viable []cloudprovider.InstanceType
+	for _, it := range instanceTypes {
+		zones, ok := nrtCache.ZonesForInstanceType(it)
+		if !ok {
+			continue
+		}
+		for _, zone := range zones {
+			if fitsSingleZone(pod, zone) {
+				viable = append(viable, it)
+				break
+			}
+		}
+	}
+	if len(viable) == 0 {
+		return nil, fmt.Errorf("no instance type has a NUMA zone that fits pod's exclusive resource requests")
+	}
+	return viable, nil
+}
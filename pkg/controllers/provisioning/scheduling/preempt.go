@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/karpenter/pkg/controllers/provisioning/scheduling/preemption"
+	"github.com/aws/karpenter/pkg/controllers/state"
+	"github.com/aws/karpenter/pkg/utils/resources"
+)
+
+// tryPreempt looks for an in-flight node where evicting some of its lower-priority pods would make room for pod,
+// returning the first viable preemption.Plan found. It returns nil (not an error) if no in-flight node can be made
+// to fit pod via preemption, since that's an expected outcome that just falls through to new-node creation.
+func (s *Scheduler) tryPreempt(pod *v1.Pod) *preemption.Plan {
+	for _, node := range s.inflight {
+		plan, err := preemption.Compute(pod, inFlightCandidate{node}, s.pdbLimits())
+		if err != nil {
+			continue
+		}
+		return plan
+	}
+	return nil
+}
+
+// applyPreemption commits an accepted preemption.Plan to scheduling state: the evicted pods are removed from the
+// node's resident pods (and, since inFlightCandidate.HostPortUsage recomputes from them on every call, from its
+// HostPortUsage too) before the incoming pod is placed. Without this, a later pod in the same Solve would recompute
+// preemption.Compute against the victims' still-resident pods and could re-select them, or find incoming's claimed
+// capacity still reported as free.
+func (s *Scheduler) applyPreemption(pod *v1.Pod, plan *preemption.Plan) error {
+	for _, node := range s.inflight {
+		if node.Node != plan.Node {
+			continue
+		}
+		node.Pods = removePods(node.Pods, plan.Evictions)
+		if err := node.Add(pod); err != nil {
+			// preemption.Compute already verified that evicting plan.Evictions frees enough room for pod; if Add
+			// still fails here, the Candidate view Compute used has diverged from the live node, which is a bug in
+			// this wiring rather than an ordinary scheduling failure.
+			return fmt.Errorf("applying preemption plan for %s: %w", client.ObjectKeyFromObject(pod), err)
+		}
+		return nil
+	}
+	return fmt.Errorf("preemption plan referenced node %s, which is no longer in-flight", plan.Node.Name)
+}
+
+// removePods returns resident with every pod named in evicted removed, identified by namespace/name.
+func removePods(resident, evicted []*v1.Pod) []*v1.Pod {
+	victims := make(map[types.NamespacedName]bool, len(evicted))
+	for _, pod := range evicted {
+		victims[client.ObjectKeyFromObject(pod)] = true
+	}
+	remaining := resident[:0:0]
+	for _, pod := range resident {
+		if !victims[client.ObjectKeyFromObject(pod)] {
+			remaining = append(remaining, pod)
+		}
+	}
+	return remaining
+}
+
+// pdbLimits lazily snapshots the cluster's PodDisruptionBudgets the first time preemption is attempted during a
+// Solve call, and reuses that snapshot for the rest of the call for consistency with how remainingResources and
+// the in-flight node list are already snapshotted once at NewScheduler time.
+func (s *Scheduler) pdbLimits() preemption.PDBLimits {
+	if s.pdbLimitsCache == nil {
+		s.pdbLimitsCache = preemption.NewPDBLimits(s.cluster.PodDisruptionBudgets())
+	}
+	return s.pdbLimitsCache
+}
+
+// inFlightCandidate adapts an *InFlightNode to preemption.Candidate.
+type inFlightCandidate struct {
+	node *InFlightNode
+}
+
+func (c inFlightCandidate) Node() *v1.Node          { return c.node.Node }
+func (c inFlightCandidate) ResidentPods() []*v1.Pod { return c.node.Pods }
+func (c inFlightCandidate) HostPortUsage() *state.HostPortUsage {
+	hostPorts := state.NewHostPortUsage()
+	for _, pod := range c.node.Pods {
+		// resident pods were already admitted once, so a conflict here would mean a bug elsewhere; ignore it and
+		// let preemption.Compute surface any resulting infeasibility through its own fit check instead.
+		_ = hostPorts.Add(pod)
+	}
+	return hostPorts
+}
+
+func (c inFlightCandidate) Allocatable() v1.ResourceList {
+	allocatable := c.node.Node.Status.Allocatable
+	for _, pod := range c.node.Pods {
+		allocatable = resources.Subtract(allocatable, resources.RequestsForPod(pod))
+	}
+	return allocatable
+}
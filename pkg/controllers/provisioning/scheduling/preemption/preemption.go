@@ -0,0 +1,206 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preemption computes the minimal set of lower-priority pods that must be evicted from an existing node
+// in order to make room for an incoming pod that doesn't otherwise fit. It's consulted by the scheduler as a
+// fallback between scheduling onto in-flight capacity as-is and provisioning a brand new node.
+package preemption
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/karpenter/pkg/controllers/state"
+	"github.com/aws/karpenter/pkg/utils/resources"
+)
+
+// Plan is the result of a successful preemption computation: evicting Evictions from Node frees enough resources
+// and host ports for the pod that triggered the computation to be placed there instead. Topology spread is not
+// checked by Compute (see below).
+type Plan struct {
+	Node      *v1.Node
+	Evictions []*v1.Pod
+}
+
+// Candidate is the subset of scheduling.InFlightNode's state that preemption needs: the node's resident pods and
+// the HostPortUsage reserved on its behalf. It's expressed as an interface rather than depending on
+// scheduling.InFlightNode directly so this package can be unit tested without constructing a full scheduler.
+type Candidate interface {
+	Node() *v1.Node
+	ResidentPods() []*v1.Pod
+	Allocatable() v1.ResourceList
+	HostPortUsage() *state.HostPortUsage
+}
+
+// PDBLimits answers whether evicting a pod would violate the PodDisruptionBudget that selects it, and commits to
+// that eviction if it wouldn't. A single PDBLimits is expected to be reused across every Compute call in the same Solve (see
+// Scheduler.pdbLimits), so that evicting one pod under a PDB is reflected in the budget available to the next pod
+// the same PDB selects, whether that pod belongs to this Compute call or a later one. Pods with no matching PDB are
+// always safe to evict from a disruption-budget standpoint.
+type PDBLimits interface {
+	// CanEvict reports whether pod can currently be evicted without violating its PodDisruptionBudget. If it
+	// returns true, the eviction is considered committed: the disruption budget of every PDB that selects pod is
+	// decremented, so a subsequent call for a different pod protected by the same PDB sees it.
+	CanEvict(pod *v1.Pod) bool
+}
+
+// Compute walks candidate's resident pods from lowest to highest priority, tentatively evicting each one whose
+// spec.priority is strictly less than incoming's, until either the incoming pod fits in the reclaimed resources
+// and host ports, or there are no more evictable pods left to try.
+//
+// Compute never evicts a pod a PDB says can't currently be evicted, and never evicts a pod with priority greater
+// than or equal to incoming's, matching the standard cascading-preemption semantics used elsewhere in Kubernetes.
+// Topology spread is intentionally not modeled here: the caller re-runs the normal topology.Update/Record path
+// against the hypothetical post-eviction pod set, since that logic already lives in scheduling.Topology and
+// shouldn't be duplicated.
+func Compute(incoming *v1.Pod, candidate Candidate, pdbs PDBLimits) (*Plan, error) {
+	incomingPriority := podPriority(incoming)
+
+	evictable := evictablePods(candidate.ResidentPods(), incomingPriority)
+	// lowest priority first: prefer evicting the pods that matter least
+	sort.Slice(evictable, func(i, j int) bool { return podPriority(evictable[i]) < podPriority(evictable[j]) })
+
+	remaining := candidate.Allocatable()
+	hostPorts := candidate.HostPortUsage().Copy()
+
+	var plan []*v1.Pod
+	for _, pod := range evictable {
+		if fits(incoming, remaining, hostPorts) {
+			break
+		}
+		// backtrack: if evicting this pod doesn't relieve the conflict that's actually blocking incoming (e.g. it
+		// doesn't hold the HostPort incoming needs and its resource footprint is negligible), skip it rather than
+		// disrupting a pod for no benefit.
+		if !relieves(incoming, pod, remaining, hostPorts) {
+			continue
+		}
+		// checked (and, on success, committed) only once we've actually decided to evict pod: checking earlier in
+		// evictablePods, before knowing which candidates are actually used, would let two pods covered by the same
+		// PDB both pass a CanEvict check taken against its un-decremented DisruptionsAllowed.
+		if !pdbs.CanEvict(pod) {
+			continue
+		}
+		hostPorts.DeletePod(podKey(pod))
+		remaining = resources.Merge(remaining, resources.RequestsForPod(pod))
+		plan = append(plan, pod)
+	}
+
+	if !fits(incoming, remaining, hostPorts) {
+		return nil, fmt.Errorf("no combination of evictable pods on %s would make room for %s", candidate.Node().Name, client.ObjectKeyFromObject(incoming))
+	}
+	return &Plan{Node: candidate.Node(), Evictions: plan}, nil
+}
+
+// evictablePods returns the resident pods that are strictly lower priority than incomingPriority. Whether each one
+// is still within its PodDisruptionBudget is checked later, at the point Compute actually decides to evict it.
+func evictablePods(resident []*v1.Pod, incomingPriority int32) []*v1.Pod {
+	var out []*v1.Pod
+	for _, pod := range resident {
+		if podPriority(pod) >= incomingPriority {
+			continue
+		}
+		out = append(out, pod)
+	}
+	return out
+}
+
+// relieves reports whether evicting pod would move us closer to incoming fitting: either it frees a HostPort
+// incoming needs, or its resource requests are non-zero against something incoming is short on.
+func relieves(incoming, pod *v1.Pod, remaining v1.ResourceList, hostPorts *state.HostPortUsage) bool {
+	probe := hostPorts.Copy()
+	probe.DeletePod(podKey(pod))
+	if probe.Add(incoming) == nil && hostPorts.Copy().Add(incoming) != nil {
+		return true
+	}
+	needed := resources.RequestsForPod(incoming)
+	for name, qty := range resources.RequestsForPod(pod) {
+		need, ok := needed[name]
+		if !ok || qty.IsZero() {
+			continue
+		}
+		if avail, ok := remaining[name]; !ok || resources.Cmp(avail, need) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fits reports whether incoming can be placed given the resources currently reclaimed and the host ports freed so
+// far. hostPorts is probed with a copy so a failed attempt doesn't leave it mutated.
+func fits(incoming *v1.Pod, remaining v1.ResourceList, hostPorts *state.HostPortUsage) bool {
+	for name, qty := range resources.RequestsForPod(incoming) {
+		if avail, ok := remaining[name]; !ok || resources.Cmp(avail, qty) < 0 {
+			return false
+		}
+	}
+	return hostPorts.Copy().Add(incoming) == nil
+}
+
+func podPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+func podKey(pod *v1.Pod) types.NamespacedName {
+	return client.ObjectKeyFromObject(pod)
+}
+
+// staticPDBLimits is a PDBLimits that consults a fixed set of PodDisruptionBudgets computed once up front, mirroring
+// how the scheduler already snapshots cluster state at the start of a Solve rather than re-reading it per pod. It
+// tracks its own running count of each PDB's remaining disruption budget, decrementing it as CanEvict commits
+// evictions, so the static PodDisruptionBudget snapshot itself never needs mutating.
+type staticPDBLimits struct {
+	pdbs      []*policyv1.PodDisruptionBudget
+	remaining map[types.NamespacedName]int32 // pdb identity -> disruptions not yet spent by a committed eviction
+}
+
+// NewPDBLimits wraps a snapshot of the cluster's PodDisruptionBudgets for use by Compute.
+func NewPDBLimits(pdbs []*policyv1.PodDisruptionBudget) PDBLimits {
+	remaining := make(map[types.NamespacedName]int32, len(pdbs))
+	for _, pdb := range pdbs {
+		remaining[client.ObjectKeyFromObject(pdb)] = pdb.Status.DisruptionsAllowed
+	}
+	return &staticPDBLimits{pdbs: pdbs, remaining: remaining}
+}
+
+func (s *staticPDBLimits) CanEvict(pod *v1.Pod) bool {
+	var matched []types.NamespacedName
+	for _, pdb := range s.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		key := client.ObjectKeyFromObject(pdb)
+		if s.remaining[key] < 1 {
+			return false
+		}
+		matched = append(matched, key)
+	}
+	for _, key := range matched {
+		s.remaining[key]--
+	}
+	return true
+}
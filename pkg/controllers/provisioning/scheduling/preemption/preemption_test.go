@@ -0,0 +1,213 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/karpenter/pkg/controllers/state"
+)
+
+type fakeCandidate struct {
+	node        *v1.Node
+	pods        []*v1.Pod
+	allocatable v1.ResourceList
+	hostPorts   *state.HostPortUsage
+}
+
+func (f *fakeCandidate) Node() *v1.Node                      { return f.node }
+func (f *fakeCandidate) ResidentPods() []*v1.Pod             { return f.pods }
+func (f *fakeCandidate) Allocatable() v1.ResourceList        { return f.allocatable.DeepCopy() }
+func (f *fakeCandidate) HostPortUsage() *state.HostPortUsage { return f.hostPorts }
+
+type alwaysEvictable struct{}
+
+func (alwaysEvictable) CanEvict(*v1.Pod) bool { return true }
+
+type neverEvictable struct{}
+
+func (neverEvictable) CanEvict(*v1.Pod) bool { return false }
+
+func priorityPtr(p int32) *int32 { return &p }
+
+func podWithCPU(name string, priority int32, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1.PodSpec{
+			Priority: priorityPtr(priority),
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+	}
+}
+
+func newNode(allocatableCPU string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse(allocatableCPU)},
+		},
+	}
+}
+
+// TestComputeCascadingEviction verifies the standard case: evicting the single lowest-priority resident pod frees
+// enough CPU for the incoming pod to fit, and it's the only pod evicted.
+func TestComputeCascadingEviction(t *testing.T) {
+	node := newNode("4")
+	low := podWithCPU("low", 0, "3")
+	resident := []*v1.Pod{low}
+	incoming := podWithCPU("incoming", 10, "2")
+
+	candidate := &fakeCandidate{
+		node:        node,
+		pods:        resident,
+		allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		hostPorts:   state.NewHostPortUsage(),
+	}
+
+	plan, err := Compute(incoming, candidate, alwaysEvictable{})
+	if err != nil {
+		t.Fatalf("Compute() returned error: %v", err)
+	}
+	if len(plan.Evictions) != 1 || plan.Evictions[0].Name != "low" {
+		t.Fatalf("expected exactly [low] to be evicted, got %v", plan.Evictions)
+	}
+}
+
+// TestComputeNeverEvictsEqualOrHigherPriority verifies that a resident pod at or above the incoming pod's priority
+// is never considered for eviction, even if evicting it would make room.
+func TestComputeNeverEvictsEqualOrHigherPriority(t *testing.T) {
+	node := newNode("4")
+	equal := podWithCPU("equal", 10, "3")
+	resident := []*v1.Pod{equal}
+	incoming := podWithCPU("incoming", 10, "2")
+
+	candidate := &fakeCandidate{
+		node:        node,
+		pods:        resident,
+		allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		hostPorts:   state.NewHostPortUsage(),
+	}
+
+	if _, err := Compute(incoming, candidate, alwaysEvictable{}); err == nil {
+		t.Fatalf("expected Compute() to fail since the only resident pod isn't strictly lower priority")
+	}
+}
+
+// TestComputeRespectsPDB verifies that a pod a PDB disallows evicting is skipped even though it's low priority and
+// evicting it would otherwise free enough room.
+func TestComputeRespectsPDB(t *testing.T) {
+	node := newNode("4")
+	low := podWithCPU("low", 0, "3")
+	resident := []*v1.Pod{low}
+	incoming := podWithCPU("incoming", 10, "2")
+
+	candidate := &fakeCandidate{
+		node:        node,
+		pods:        resident,
+		allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		hostPorts:   state.NewHostPortUsage(),
+	}
+
+	if _, err := Compute(incoming, candidate, neverEvictable{}); err == nil {
+		t.Fatalf("expected Compute() to fail since the only evictable pod is protected by a PDB")
+	}
+}
+
+// TestComputeEvictsPodHoldingConflictingHostPort verifies the host-port conflict case: the incoming pod doesn't
+// need any extra CPU, but it needs a HostPort that's held by a lower-priority resident pod. This exercises the
+// fix for relieves() mutating the live HostPortUsage instead of a copy, which previously made every subsequent fit
+// check against that node self-conflict and falsely report no plan was possible.
+func TestComputeEvictsPodHoldingConflictingHostPort(t *testing.T) {
+	node := newNode("4")
+	holder := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "holder"},
+		Spec: v1.PodSpec{
+			Priority: priorityPtr(0),
+			Containers: []v1.Container{{
+				Ports: []v1.ContainerPort{{HostPort: 8080, Protocol: v1.ProtocolTCP}},
+			}},
+		},
+	}
+	resident := []*v1.Pod{holder}
+	incoming := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "incoming"},
+		Spec: v1.PodSpec{
+			Priority: priorityPtr(10),
+			Containers: []v1.Container{{
+				Ports: []v1.ContainerPort{{HostPort: 8080, Protocol: v1.ProtocolTCP}},
+			}},
+		},
+	}
+
+	candidate := &fakeCandidate{
+		node:        node,
+		pods:        resident,
+		allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+		hostPorts:   state.NewHostPortUsage(),
+	}
+	if err := candidate.hostPorts.Add(holder); err != nil {
+		t.Fatalf("failed to seed HostPortUsage: %v", err)
+	}
+
+	plan, err := Compute(incoming, candidate, alwaysEvictable{})
+	if err != nil {
+		t.Fatalf("Compute() returned error: %v", err)
+	}
+	if len(plan.Evictions) != 1 || plan.Evictions[0].Name != "holder" {
+		t.Fatalf("expected exactly [holder] to be evicted to free the HostPort, got %v", plan.Evictions)
+	}
+}
+
+// TestComputeRespectsPDBAcrossMultipleEvictionsInOneCall verifies that a PDB's DisruptionsAllowed is spent as
+// pods are committed to the plan, not re-checked against its un-decremented status for every candidate: two
+// resident pods both covered by a PDB with DisruptionsAllowed: 1 must not both be evicted in a single Compute call,
+// even if evicting both really would make room for incoming.
+func TestComputeRespectsPDBAcrossMultipleEvictionsInOneCall(t *testing.T) {
+	node := newNode("4")
+	podLabels := map[string]string{"app": "protected"}
+	low1 := podWithCPU("low1", 0, "2")
+	low1.Labels = podLabels
+	low2 := podWithCPU("low2", 0, "2")
+	low2.Labels = podLabels
+	resident := []*v1.Pod{low1, low2}
+	// incoming needs more than either single eviction could free, so a plan that (incorrectly) evicts both would
+	// otherwise succeed.
+	incoming := podWithCPU("incoming", 10, "5")
+
+	candidate := &fakeCandidate{
+		node:        node,
+		pods:        resident,
+		allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		hostPorts:   state.NewHostPortUsage(),
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "protected-pdb"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: podLabels}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+
+	if _, err := Compute(incoming, candidate, NewPDBLimits([]*policyv1.PodDisruptionBudget{pdb})); err == nil {
+		t.Fatalf("expected Compute() to fail since satisfying incoming would require violating the PDB's budget of 1")
+	}
+}
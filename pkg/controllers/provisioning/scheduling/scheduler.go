@@ -27,14 +27,21 @@ import (
 
 	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
 	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/controllers/provisioning/scheduling/preemption"
 	"github.com/aws/karpenter/pkg/controllers/state"
 	"github.com/aws/karpenter/pkg/events"
 	"github.com/aws/karpenter/pkg/scheduling"
+	"github.com/aws/karpenter/pkg/scheduling/framework"
+	"github.com/aws/karpenter/pkg/scheduling/framework/plugins"
 	"github.com/aws/karpenter/pkg/utils/resources"
 )
 
-func NewScheduler(nodeTemplates []*scheduling.NodeTemplate, provisioners []v1alpha5.Provisioner, cluster *state.Cluster, topology *Topology, instanceTypes []cloudprovider.InstanceType, daemonOverhead map[*scheduling.NodeTemplate]v1.ResourceList, recorder events.Recorder) *Scheduler {
+func NewScheduler(nodeTemplates []*scheduling.NodeTemplate, provisioners []v1alpha5.Provisioner, cluster *state.Cluster, topology *Topology, instanceTypes []cloudprovider.InstanceType, daemonOverhead map[*scheduling.NodeTemplate]v1.ResourceList, recorder events.Recorder, nrtCache *state.NRTCache) *Scheduler {
 	sort.Slice(instanceTypes, func(i, j int) bool { return instanceTypes[i].Price() < instanceTypes[j].Price() })
+	// each Scheduler gets its own registry holding only the built-in plugins; a profile that names any other plugin
+	// fails to resolve in RunFilters/RunScore
+	registry := framework.NewRegistry()
+	plugins.RegisterBuiltins(registry)
 	s := &Scheduler{
 		nodeTemplates:      nodeTemplates,
 		topology:           topology,
@@ -44,6 +51,21 @@ func NewScheduler(nodeTemplates []*scheduling.NodeTemplate, provisioners []v1alp
 		recorder:           recorder,
 		preferences:        &Preferences{},
 		remainingResources: map[string]v1.ResourceList{},
+		registry:           registry,
+		profiles:           map[string]framework.Profile{},
+		nrtCache:           nrtCache,
+	}
+
+	if s.nrtCache != nil {
+		for _, it := range instanceTypes {
+			if _, ok := s.nrtCache.ZonesForInstanceType(it); !ok {
+				// The cache has no zone layout for this instance type. Seed a single-zone approximation treating the
+				// whole instance as one NUMA zone so a NUMA-sensitive pod can still be considered for it instead of
+				// unconditionally failing to place anywhere. The seeded layout stays in place: observed
+				// NodeResourceTopology CRs update per-node layouts only, not per-instance-type ones.
+				s.nrtCache.AddInstanceType(it, []state.ZoneResources{{Resources: it.Resources()}})
+			}
+		}
 	}
 
 	namedNodeTemplates := lo.KeyBy(s.nodeTemplates, func(nodeTemplate *scheduling.NodeTemplate) string {
@@ -54,6 +76,8 @@ func NewScheduler(nodeTemplates []*scheduling.NodeTemplate, provisioners []v1alp
 		if provisioner.Spec.Limits != nil {
 			s.remainingResources[provisioner.Name] = provisioner.Spec.Limits.Resources
 		}
+		// provisioners that don't reference a named profile keep the historical, non-pluggable behavior
+		s.profiles[provisioner.Name] = framework.ProfileByName(string(provisioner.Spec.SchedulerProfile))
 	}
 
 	// create our in-flight nodes
@@ -90,6 +114,10 @@ type Scheduler struct {
 	topology           *Topology
 	cluster            *state.Cluster
 	recorder           events.Recorder
+	pdbLimitsCache     preemption.PDBLimits
+	registry           *framework.Registry
+	profiles           map[string]framework.Profile // provisioner name -> the Filter/Score chain it schedules with
+	nrtCache           *state.NRTCache              // nil if NodeResourceTopology awareness isn't configured
 }
 
 func (s *Scheduler) Solve(ctx context.Context, pods []*v1.Pod) ([]*Node, error) {
@@ -125,6 +153,83 @@ func (s *Scheduler) Solve(ctx context.Context, pods []*v1.Pod) ([]*Node, error)
 	return s.nodes, nil
 }
 
+// SolveBatch schedules a bounded batch of pods, grouped under a single request key, with all-or-nothing semantics:
+// either every pod in pods schedules, or none of them do and no new nodes are created on the batch's behalf. It
+// works by running the normal greedy loop against a shadow copy of the scheduler's node state, and only merging
+// that shadow state back in (committing the nodes for actual launch) if nothing in the batch failed to schedule.
+func (s *Scheduler) SolveBatch(ctx context.Context, requestKey string, pods []*v1.Pod) ([]*Node, error) {
+	shadow := s.fork()
+	forked := make(map[*Node]bool, len(shadow.nodes))
+	for _, node := range shadow.nodes {
+		forked[node] = true
+	}
+	errors := map[*v1.Pod]error{}
+	for _, pod := range pods {
+		if err := shadow.add(pod); err != nil {
+			errors[pod] = err
+		}
+	}
+	if len(errors) > 0 {
+		return nil, multierr.Combine(lo.Values(errors)...)
+	}
+	// every pod in the batch scheduled against the shadow state, so commit it back to the real scheduler. Only the
+	// nodes created for this batch need to be launched: shadow.nodes also contains every node the scheduler already
+	// knew about (fork seeds it from s.nodes), and those were either already launched by an earlier call or are
+	// someone else's responsibility to launch. add re-sorts shadow.nodes, so the new nodes are told apart from the
+	// forked ones by identity rather than by position.
+	var newNodes []*Node
+	for _, node := range shadow.nodes {
+		if !forked[node] {
+			newNodes = append(newNodes, node)
+		}
+	}
+	s.nodes = shadow.nodes
+	s.inflight = shadow.inflight
+	s.remainingResources = shadow.remainingResources
+	return newNodes, nil
+}
+
+// fork returns a copy of the scheduler whose mutable scheduling state (nodes, in-flight nodes, and remaining
+// resource accounting) can be mutated independently of the original without affecting it. This lets SolveBatch
+// try a batch speculatively and discard the attempt on failure instead of leaving partially-scheduled state behind.
+// Node.Add and InFlightNode.Add mutate their receiver in place, so the elements themselves, not just the slices
+// holding them, are deep-copied: a shallow copy would let a batch that schedules several pods before failing on a
+// later one leave those placements applied to the real scheduler's nodes even though the batch as a whole never
+// commits.
+func (s *Scheduler) fork() *Scheduler {
+	remainingResources := map[string]v1.ResourceList{}
+	for provisionerName, resourceList := range s.remainingResources {
+		rl := v1.ResourceList{}
+		for name, qty := range resourceList {
+			rl[name] = qty.DeepCopy()
+		}
+		remainingResources[provisionerName] = rl
+	}
+	nodes := make([]*Node, len(s.nodes))
+	for i, node := range s.nodes {
+		nodes[i] = node.DeepCopy()
+	}
+	inflight := make([]*InFlightNode, len(s.inflight))
+	for i, node := range s.inflight {
+		inflight[i] = node.DeepCopy()
+	}
+	return &Scheduler{
+		nodes:              nodes,
+		inflight:           inflight,
+		nodeTemplates:      s.nodeTemplates,
+		remainingResources: remainingResources,
+		instanceTypes:      s.instanceTypes,
+		daemonOverhead:     s.daemonOverhead,
+		preferences:        s.preferences,
+		topology:           s.topology,
+		cluster:            s.cluster,
+		recorder:           s.recorder,
+		registry:           s.registry,
+		profiles:           s.profiles,
+		nrtCache:           s.nrtCache,
+	}
+}
+
 func (s *Scheduler) recordSchedulingResults(ctx context.Context, failedToSchedule []*v1.Pod, errors map[*v1.Pod]error) {
 	// notify users of pods that can schedule to inflight capacity
 	existingCount := 0
@@ -157,6 +262,17 @@ func (s *Scheduler) add(pod *v1.Pod) error {
 		}
 	}
 
+	// Next, see if preempting lower-priority pods on an in-flight node would make room. We only attempt this once
+	// every in-flight node has rejected the pod as-is, since preemption is disruptive; it's tried before the planned
+	// nodes below and before creating a new node.
+	if plan := s.tryPreempt(pod); plan != nil {
+		if err := s.applyPreemption(pod, plan); err != nil {
+			return err
+		}
+		s.recorder.PodShouldPreempt(pod, plan.Node, plan.Evictions)
+		return nil
+	}
+
 	// Consider using https://pkg.go.dev/container/heap
 	sort.Slice(s.nodes, func(a, b int) bool { return len(s.nodes[a].Pods) < len(s.nodes[b].Pods) })
 
@@ -180,8 +296,38 @@ func (s *Scheduler) add(pod *v1.Pod) error {
 			}
 		}
 
+		// a Guaranteed-QoS pod requesting whole CPUs, devices, or hugepages will only admit onto a kubelet running
+		// with TopologyManagerPolicy=single-numa-node, so narrow candidates down to instance types whose NUMA zone
+		// layout can actually satisfy it before anything else gets to pick among them.
+		if s.nrtCache != nil && requiresSingleNUMANode(pod) {
+			narrowed, err := filterInstanceTypesByNRT(pod, instanceTypes, s.nrtCache)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			instanceTypes = narrowed
+		}
+
+		// run the provisioner's configured Filter/Score plugin chain, falling back to the default profile (which
+		// reproduces the pre-framework hardcoded behavior) for provisioners that don't reference one
+		profile := s.profiles[nodeTemplate.ProvisionerName]
+		filtered, err := s.registry.RunFilters(profile, pod, nodeTemplate, instanceTypes)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		if len(filtered) == 0 {
+			errs = multierr.Append(errs, fmt.Errorf("no instance type satisfies scheduler profile %q for provisioner %q", profile.Name, nodeTemplate.ProvisionerName))
+			continue
+		}
+		instanceTypes, err = s.registry.RunScore(profile, pod, nodeTemplate, filtered)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
 		node := NewNode(nodeTemplate, s.topology, s.daemonOverhead[nodeTemplate], instanceTypes)
-		err := node.Add(pod)
+		err = node.Add(pod)
 		if err == nil {
 			s.nodes = append(s.nodes, node)
 			// we will launch this node and need to track its maximum possible resource usage against our remaining resources
@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stateRetryPeriod is how often NodeController re-reconciles a node it already knows about, keeping cluster state
+// reasonably fresh without a watch on every field that could change it.
+const stateRetryPeriod = 30 * time.Second
+
+// Node is Karpenter's cached view of a node that's expensive to recompute on every scheduling loop: the node
+// object itself and the total resources it's known to have allocated.
+type Node struct {
+	Node     *v1.Node
+	Capacity v1.ResourceList
+}
+
+// Cluster maintains an up-to-date view of every node in the cluster for the scheduler and consolidator to consult
+// without re-listing nodes from the API server on every call.
+type Cluster struct {
+	mu    sync.RWMutex
+	nodes map[string]*Node
+	pdbs  map[string]*policyv1.PodDisruptionBudget
+}
+
+// NewCluster constructs an empty Cluster. Nodes are added to it as NodeController observes them.
+func NewCluster() *Cluster {
+	return &Cluster{
+		nodes: map[string]*Node{},
+		pdbs:  map[string]*policyv1.PodDisruptionBudget{},
+	}
+}
+
+// ForEachNode calls f with every node currently known to the cluster, stopping early if f returns false.
+func (c *Cluster) ForEachNode(f func(node *Node) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, node := range c.nodes {
+		if !f(node) {
+			return
+		}
+	}
+}
+
+// updateNode records the latest observed state of node, computing its allocated capacity from scratch so the
+// cached view stays consistent even if we missed an intermediate update.
+func (c *Cluster) updateNode(node *v1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes[node.Name] = &Node{Node: node, Capacity: node.Status.Allocatable}
+}
+
+// deleteNode removes a node that no longer exists from the cached view.
+func (c *Cluster) deleteNode(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.nodes, name)
+}
+
+// PodDisruptionBudgets returns every PodDisruptionBudget currently known to the cluster, for preemption to consult
+// before evicting a resident pod.
+func (c *Cluster) PodDisruptionBudgets() []*policyv1.PodDisruptionBudget {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	pdbs := make([]*policyv1.PodDisruptionBudget, 0, len(c.pdbs))
+	for _, pdb := range c.pdbs {
+		pdbs = append(pdbs, pdb)
+	}
+	return pdbs
+}
+
+// updatePDB records the latest observed state of a PodDisruptionBudget.
+func (c *Cluster) updatePDB(pdb *policyv1.PodDisruptionBudget) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pdbs[client.ObjectKeyFromObject(pdb).String()] = pdb
+}
+
+// deletePDB removes a PodDisruptionBudget that no longer exists from the cached view.
+func (c *Cluster) deletePDB(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pdbs, key)
+}
@@ -0,0 +1,223 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
+	"github.com/aws/karpenter/pkg/events"
+)
+
+// RebalanceMove is a single proposed eviction: moving pod off of a node that's carrying more than its even share
+// of the group's load, onto one that's carrying less.
+type RebalanceMove struct {
+	Pod  *v1.Pod
+	From *v1.Node
+	To   *v1.Node
+}
+
+// RebalancePlan is the set of moves that would bring a provisioner + instance-type-family group back under its
+// configured skew threshold.
+type RebalancePlan struct {
+	Moves []RebalanceMove
+}
+
+// loadedNode pairs an in-flight node with the pods currently bound to it, which is the view the even-spread
+// invariant is computed over.
+type loadedNode struct {
+	node *v1.Node
+	pods []v1.Pod
+}
+
+// nodeGroup is one provisioner + instance-type-family's worth of nodes, the unit the consolidator balances within.
+// Nodes launched by different provisioners, or by the same provisioner but a different instance family, are never
+// compared against each other since their allocatable capacity isn't directly comparable.
+type nodeGroup struct {
+	key   string
+	nodes []*loadedNode
+}
+
+// simulator re-plays the existing InFlightNode.Add placement logic against a candidate node so the consolidator
+// can check whether moving a pod there would violate topology, HostPort, or affinity constraints without actually
+// mutating cluster state.
+type simulator interface {
+	// CanAdd reports whether pod could be placed on node without violating any scheduling constraint.
+	CanAdd(node *v1.Node, pod *v1.Pod) bool
+}
+
+// EvenSpreadConsolidator periodically evaluates whether pods are evenly spread across the nodes launched by each
+// provisioner + instance-type family, and proposes evictions to reduce max-min skew. Unlike the scale-down
+// consolidator, it never fully empties a node — it only reduces fragmentation between nodes that are all staying.
+type EvenSpreadConsolidator struct {
+	kubeClient client.Client
+	cluster    *Cluster
+	simulator  simulator
+	recorder   events.Recorder
+}
+
+// NewEvenSpreadConsolidator constructs a consolidator. The skew threshold that triggers a rebalance is read per
+// group from the owning Provisioner's Spec.ConsolidationPolicy.Threshold, defaulting to defaultSkewThreshold.
+func NewEvenSpreadConsolidator(kubeClient client.Client, cluster *Cluster, sim simulator, recorder events.Recorder) *EvenSpreadConsolidator {
+	return &EvenSpreadConsolidator{
+		kubeClient: kubeClient,
+		cluster:    cluster,
+		simulator:  sim,
+		recorder:   recorder,
+	}
+}
+
+// defaultSkewThreshold is used for provisioners that enable ConsolidationPolicy without specifying a threshold.
+const defaultSkewThreshold = 2
+
+// Reconcile groups the cluster's in-flight nodes and, for any group whose pod-count skew exceeds its threshold,
+// emits eviction events that move pods off the most-loaded node and onto the least-loaded one. It returns the
+// plans it acted on (groups whose plan has no moves are left out) primarily to make the decision observable in tests.
+func (c *EvenSpreadConsolidator) Reconcile(ctx context.Context, provisioners []v1alpha5.Provisioner) ([]RebalancePlan, error) {
+	enabled := map[string]*v1alpha5.ConsolidationPolicy{}
+	for i := range provisioners {
+		if provisioners[i].Spec.ConsolidationPolicy != nil {
+			enabled[provisioners[i].Name] = provisioners[i].Spec.ConsolidationPolicy
+		}
+	}
+
+	groups, err := c.groupNodes(ctx, enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []RebalancePlan
+	for _, group := range groups {
+		threshold := defaultSkewThreshold
+		if policy := enabled[provisionerOf(group.key)]; policy != nil && policy.Threshold > 0 {
+			threshold = policy.Threshold
+		}
+		plan := c.planGroup(group, threshold)
+		if len(plan.Moves) == 0 {
+			continue
+		}
+		for _, move := range plan.Moves {
+			c.recorder.PodShouldEvict(move.Pod, move.From, move.To)
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// groupNodes buckets the cluster's nodes whose provisioner has consolidation enabled by provisioner name +
+// instance-type family, and fetches the pods currently bound to each one.
+func (c *EvenSpreadConsolidator) groupNodes(ctx context.Context, enabled map[string]*v1alpha5.ConsolidationPolicy) ([]nodeGroup, error) {
+	byKey := map[string][]*loadedNode{}
+	var listErr error
+	c.cluster.ForEachNode(func(node *Node) bool {
+		provisionerName, ok := node.Node.Labels[v1alpha5.ProvisionerNameLabelKey]
+		if !ok {
+			// ignoring this node as it wasn't launched by us
+			return true
+		}
+		if _, ok := enabled[provisionerName]; !ok {
+			// this provisioner hasn't opted into consolidation
+			return true
+		}
+		pods := &v1.PodList{}
+		if err := c.kubeClient.List(ctx, pods, client.MatchingFields{"spec.nodeName": node.Node.Name}); err != nil {
+			listErr = err
+			return false
+		}
+		key := groupKey(node.Node, provisionerName)
+		byKey[key] = append(byKey[key], &loadedNode{node: node.Node, pods: pods.Items})
+		return true
+	})
+	if listErr != nil {
+		return nil, listErr
+	}
+	var groups []nodeGroup
+	for key, nodes := range byKey {
+		if len(nodes) < 2 {
+			// a single node can't be unevenly loaded relative to itself
+			continue
+		}
+		groups = append(groups, nodeGroup{key: key, nodes: nodes})
+	}
+	return groups, nil
+}
+
+func groupKey(node *v1.Node, provisionerName string) string {
+	family := node.Labels[v1alpha5.InstanceTypeFamilyLabelKey]
+	return provisionerName + "/" + family
+}
+
+func provisionerOf(groupKey string) string {
+	for i, c := range groupKey {
+		if c == '/' {
+			return groupKey[:i]
+		}
+	}
+	return groupKey
+}
+
+// planGroup computes the rebalance plan for a single group: if the spread between its most- and least-loaded node
+// exceeds threshold, it moves pods one at a time from the most-loaded node to the least-loaded one (re-running the
+// simulator on each candidate move) until the skew is back at or under the threshold or no more pods can be moved.
+func (c *EvenSpreadConsolidator) planGroup(group nodeGroup, threshold int) RebalancePlan {
+	nodes := append([]*loadedNode{}, group.nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return len(nodes[i].pods) < len(nodes[j].pods) })
+
+	var moves []RebalanceMove
+	for skew(nodes) > threshold {
+		least, most := nodes[0], nodes[len(nodes)-1]
+		moved := false
+		for i := range most.pods {
+			// copy the pod by value before mutating most.pods: removing element i below re-slices its backing
+			// array, which would otherwise silently corrupt the *v1.Pod captured in an already-recorded
+			// RebalanceMove the next time this loop appends past it.
+			pod := most.pods[i]
+			if !c.simulator.CanAdd(least.node, &pod) {
+				continue
+			}
+			moves = append(moves, RebalanceMove{Pod: &pod, From: most.node, To: least.node})
+			most.pods = append(most.pods[:i], most.pods[i+1:]...)
+			least.pods = append(least.pods, pod)
+			moved = true
+			break
+		}
+		if !moved {
+			// nothing on the most-loaded node can be re-simulated onto the least-loaded one without violating a
+			// constraint; further iteration won't help so stop here rather than looping forever.
+			break
+		}
+		sort.Slice(nodes, func(i, j int) bool { return len(nodes[i].pods) < len(nodes[j].pods) })
+	}
+	return RebalancePlan{Moves: moves}
+}
+
+func skew(nodes []*loadedNode) int {
+	if len(nodes) == 0 {
+		return 0
+	}
+	min, max := len(nodes[0].pods), len(nodes[0].pods)
+	for _, node := range nodes {
+		if c := len(node.pods); c < min {
+			min = c
+		} else if c > max {
+			max = c
+		}
+	}
+	return max - min
+}
@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// alwaysFitsSimulator lets every candidate move succeed, exercising planGroup's own bookkeeping without any
+// constraint getting in the way.
+type alwaysFitsSimulator struct{}
+
+func (alwaysFitsSimulator) CanAdd(*v1.Node, *v1.Pod) bool { return true }
+
+// neverFitsSimulator rejects every candidate move, so planGroup should give up without moving anything.
+type neverFitsSimulator struct{}
+
+func (neverFitsSimulator) CanAdd(*v1.Node, *v1.Pod) bool { return false }
+
+func podNamed(name string) v1.Pod {
+	return v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+}
+
+func nodeNamed(name string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestSkew(t *testing.T) {
+	nodes := []*loadedNode{
+		{node: nodeNamed("a"), pods: []v1.Pod{podNamed("p1"), podNamed("p2"), podNamed("p3")}},
+		{node: nodeNamed("b"), pods: []v1.Pod{podNamed("p4")}},
+	}
+	if got := skew(nodes); got != 2 {
+		t.Fatalf("skew() = %d, want 2", got)
+	}
+}
+
+// TestPlanGroupMovesUntilUnderThreshold verifies the standard case: pods move one at a time from the most-loaded
+// node to the least-loaded one until the skew is back at or under the threshold.
+func TestPlanGroupMovesUntilUnderThreshold(t *testing.T) {
+	c := &EvenSpreadConsolidator{simulator: alwaysFitsSimulator{}}
+	group := nodeGroup{
+		key: "default/m5",
+		nodes: []*loadedNode{
+			{node: nodeNamed("busy"), pods: []v1.Pod{podNamed("p1"), podNamed("p2"), podNamed("p3")}},
+			{node: nodeNamed("idle"), pods: nil},
+		},
+	}
+
+	plan := c.planGroup(group, 1)
+	if len(plan.Moves) != 1 {
+		t.Fatalf("expected exactly 1 move to bring skew to 1, got %d: %+v", len(plan.Moves), plan.Moves)
+	}
+	if plan.Moves[0].From.Name != "busy" || plan.Moves[0].To.Name != "idle" {
+		t.Fatalf("expected a move from busy to idle, got %+v", plan.Moves[0])
+	}
+}
+
+// TestPlanGroupStopsWhenSimulatorRejectsEveryMove verifies planGroup gives up without moving anything rather than
+// looping forever when no candidate move is simulator-viable.
+func TestPlanGroupStopsWhenSimulatorRejectsEveryMove(t *testing.T) {
+	c := &EvenSpreadConsolidator{simulator: neverFitsSimulator{}}
+	group := nodeGroup{
+		key: "default/m5",
+		nodes: []*loadedNode{
+			{node: nodeNamed("busy"), pods: []v1.Pod{podNamed("p1"), podNamed("p2"), podNamed("p3")}},
+			{node: nodeNamed("idle"), pods: nil},
+		},
+	}
+
+	plan := c.planGroup(group, 1)
+	if len(plan.Moves) != 0 {
+		t.Fatalf("expected no moves since the simulator rejects everything, got %+v", plan.Moves)
+	}
+}
+
+// TestPlanGroupRecordedMovesSurviveLaterSliceMutation guards the planGroup pointer-safety fix: RebalanceMove.Pod
+// must keep pointing at the pod that was actually selected for eviction even after most.pods is re-sliced by a
+// later iteration of the loop.
+func TestPlanGroupRecordedMovesSurviveLaterSliceMutation(t *testing.T) {
+	c := &EvenSpreadConsolidator{simulator: alwaysFitsSimulator{}}
+	group := nodeGroup{
+		key: "default/m5",
+		nodes: []*loadedNode{
+			{node: nodeNamed("busy"), pods: []v1.Pod{podNamed("p1"), podNamed("p2"), podNamed("p3"), podNamed("p4")}},
+			{node: nodeNamed("idle"), pods: nil},
+		},
+	}
+
+	plan := c.planGroup(group, 1)
+	seen := map[string]bool{}
+	for _, move := range plan.Moves {
+		if move.Pod == nil {
+			t.Fatalf("move has a nil Pod: %+v", move)
+		}
+		if seen[move.Pod.Name] {
+			t.Fatalf("pod %s was recorded in more than one move: %+v", move.Pod.Name, plan.Moves)
+		}
+		seen[move.Pod.Name] = true
+	}
+}
@@ -16,6 +16,8 @@ package state
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -24,21 +26,39 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/karpenter/pkg/apis/provisioning/v1alpha5"
 )
 
 const nodeControllerName = "node-state"
 
+// consolidationPeriod is the minimum interval between even-spread consolidation passes. Even-spread skew is a
+// cluster-wide property, not a per-node one, so NodeController gates how often it re-evaluates it rather than
+// recomputing every group's skew on every single node's Reconcile, which would scale with cluster size for no
+// benefit since the answer doesn't change between nodes reconciling moments apart.
+const consolidationPeriod = time.Minute
+
 // NodeController reconciles nodes for the purpose of maintaining state regarding nodes that is expensive to compute.
+// As an additional periodic pass, gated by consolidationPeriod, it also evaluates even-spread consolidation across
+// every provisioner that has opted into ConsolidationPolicy.
 type NodeController struct {
-	kubeClient client.Client
-	cluster    *Cluster
+	kubeClient    client.Client
+	cluster       *Cluster
+	consolidation *EvenSpreadConsolidator
+	nrtCache      *NRTCache // nil if NodeResourceTopology awareness isn't configured
+
+	consolidationMu   sync.Mutex
+	lastConsolidation time.Time
 }
 
-// NewNodeController constructs a controller instance
-func NewNodeController(kubeClient client.Client, cluster *Cluster) *NodeController {
+// NewNodeController constructs a controller instance. nrtCache may be nil, in which case foreign-pod detection is
+// skipped, matching how the scheduler itself treats a nil NRTCache as "NUMA awareness isn't configured".
+func NewNodeController(kubeClient client.Client, cluster *Cluster, consolidation *EvenSpreadConsolidator, nrtCache *NRTCache) *NodeController {
 	return &NodeController{
-		kubeClient: kubeClient,
-		cluster:    cluster,
+		kubeClient:    kubeClient,
+		cluster:       cluster,
+		consolidation: consolidation,
+		nrtCache:      nrtCache,
 	}
 }
 
@@ -56,9 +76,61 @@ func (c *NodeController) Reconcile(ctx context.Context, req reconcile.Request) (
 	// ensure it's aware of any nodes we discover, this is a no-op if the node is already known to our cluster state
 	c.cluster.updateNode(node)
 
+	if c.nrtCache != nil {
+		if err := c.observeExclusiveResourcePods(ctx, node); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if c.shouldRunConsolidation() {
+		if err := c.runConsolidation(ctx); err != nil {
+			logging.FromContext(ctx).Errorf("evaluating even-spread consolidation, %s", err)
+		}
+	}
+
 	return reconcile.Result{Requeue: true, RequeueAfter: stateRetryPeriod}, nil
 }
 
+// observeExclusiveResourcePods lists the pods currently bound to node and hands them to nrtCache.ObservePods, so a
+// pod claiming exclusive NUMA resources that this cache hadn't previously accounted for marks the node's cached
+// zone layout dirty, so ZonesForNode reports it as not fresh.
+func (c *NodeController) observeExclusiveResourcePods(ctx context.Context, node *v1.Node) error {
+	pods := &v1.PodList{}
+	if err := c.kubeClient.List(ctx, pods, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return err
+	}
+	bound := make([]*v1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		bound[i] = &pods.Items[i]
+	}
+	c.nrtCache.ObservePods(node.Name, bound)
+	return nil
+}
+
+// shouldRunConsolidation reports whether at least consolidationPeriod has passed since the last even-spread pass,
+// and if so, claims this Reconcile call as the one that runs it so concurrent reconciles of other nodes don't also
+// run it for the same period.
+func (c *NodeController) shouldRunConsolidation() bool {
+	c.consolidationMu.Lock()
+	defer c.consolidationMu.Unlock()
+	if time.Since(c.lastConsolidation) < consolidationPeriod {
+		return false
+	}
+	c.lastConsolidation = time.Now()
+	return true
+}
+
+// runConsolidation lists the provisioners that have opted into ConsolidationPolicy and evaluates whether any of
+// their node groups need rebalancing.
+func (c *NodeController) runConsolidation(ctx context.Context) error {
+	provisionerList := &v1alpha5.ProvisionerList{}
+	if err := c.kubeClient.List(ctx, provisionerList); err != nil {
+		return err
+	}
+	_, err := c.consolidation.Reconcile(ctx, provisionerList.Items)
+	return err
+}
+
 func (c *NodeController) Register(ctx context.Context, m manager.Manager) error {
 	return controllerruntime.
 		NewControllerManagedBy(m).
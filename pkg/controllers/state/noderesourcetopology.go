@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/logging"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const nrtControllerName = "noderesourcetopology-state"
+
+// NodeResourceTopologyController reconciles NodeResourceTopology objects, which are named after and report the
+// real NUMA zone layout of the node they describe, keeping NRTCache's view of existing nodes up to date. Without
+// it, NRTCache.ZonesForNode never has anything to return.
+type NodeResourceTopologyController struct {
+	kubeClient client.Client
+	nrtCache   *NRTCache
+}
+
+// NewNodeResourceTopologyController constructs a controller instance.
+func NewNodeResourceTopologyController(kubeClient client.Client, nrtCache *NRTCache) *NodeResourceTopologyController {
+	return &NodeResourceTopologyController{
+		kubeClient: kubeClient,
+		nrtCache:   nrtCache,
+	}
+}
+
+func (c *NodeResourceTopologyController) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).Named(nrtControllerName).With("node", req.Name))
+	nrt := &topologyv1alpha1.NodeResourceTopology{}
+	if err := c.kubeClient.Get(ctx, req.NamespacedName, nrt); err != nil {
+		if errors.IsNotFound(err) {
+			c.nrtCache.DeleteNode(req.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	c.nrtCache.UpdateFromCR(nrt)
+	return reconcile.Result{Requeue: true, RequeueAfter: stateRetryPeriod}, nil
+}
+
+func (c *NodeResourceTopologyController) Register(ctx context.Context, m manager.Manager) error {
+	return controllerruntime.
+		NewControllerManagedBy(m).
+		Named(nrtControllerName).
+		For(&topologyv1alpha1.NodeResourceTopology{}).
+		Complete(c)
+}
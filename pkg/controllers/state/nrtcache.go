@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"sync"
+
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+)
+
+// ZoneResources is the allocatable resource budget of a single NUMA zone, derived either from an observed
+// NodeResourceTopology CR (for existing nodes) or from instance type metadata (for simulating a not-yet-launched
+// node of a given instance type).
+type ZoneResources struct {
+	Name      string
+	Resources v1.ResourceList
+}
+
+// nodeTopology is the per-node state the NRTCache tracks: its zone layout, and whether that layout needs to be
+// refreshed from the API server before it can be trusted again.
+type nodeTopology struct {
+	zones []ZoneResources
+	dirty bool
+}
+
+// NRTCache watches NodeResourceTopology (NRT) objects for existing nodes and models, for each known
+// cloudprovider.InstanceType, the per-zone resource budget (CPU, memory, hugepages, devices) that a new node of
+// that type would offer. The scheduler consults it so that pods requiring single-NUMA-node placement can be
+// simulated against a realistic zone layout instead of assuming all of a node's resources are fungible.
+type NRTCache struct {
+	mu sync.RWMutex
+	// observed holds the last-known zone layout per existing node, keyed by node name.
+	observed map[string]*nodeTopology
+	// instanceTypeZones holds the simulated zone layout for a not-yet-launched node of a given instance type,
+	// keyed by instance type name. This is derived once from instance metadata (vCPU/memory/hugepage topology)
+	// and doesn't change at runtime.
+	instanceTypeZones map[string][]ZoneResources
+	// knownExclusivePods tracks, per node, which pods were last observed claiming exclusive NUMA resources on it.
+	// ObservePods diffs against this to notice a pod appearing that wasn't accounted for by an earlier observation.
+	knownExclusivePods map[string]map[types.NamespacedName]bool
+}
+
+// NewNRTCache constructs an empty NRTCache. Call UpdateFromCR as NodeResourceTopology objects are observed and
+// AddInstanceType to seed the simulated layout for each supported instance type.
+func NewNRTCache() *NRTCache {
+	return &NRTCache{
+		observed:           map[string]*nodeTopology{},
+		instanceTypeZones:  map[string][]ZoneResources{},
+		knownExclusivePods: map[string]map[types.NamespacedName]bool{},
+	}
+}
+
+// UpdateFromCR records the zone layout observed on an existing node's NodeResourceTopology CR, clearing any
+// previous dirty mark since this is a fresh read from the API server.
+func (c *NRTCache) UpdateFromCR(nrt *topologyv1alpha1.NodeResourceTopology) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zones []ZoneResources
+	for _, zone := range nrt.Zones {
+		rl := v1.ResourceList{}
+		for _, res := range zone.Resources {
+			rl[v1.ResourceName(res.Name)] = res.Allocatable.DeepCopy()
+		}
+		zones = append(zones, ZoneResources{Name: zone.Name, Resources: rl})
+	}
+	c.observed[nrt.Name] = &nodeTopology{zones: zones}
+}
+
+// DeleteNode drops a node's observed zone layout, e.g. once its NodeResourceTopology CR no longer exists.
+func (c *NRTCache) DeleteNode(nodeName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.observed, nodeName)
+	delete(c.knownExclusivePods, nodeName)
+}
+
+// AddInstanceType seeds the simulated per-zone resource budget used when this instance type hasn't been launched
+// yet, so the scheduler can reason about NUMA fit before a node exists to report a real NRT.
+func (c *NRTCache) AddInstanceType(it cloudprovider.InstanceType, zones []ZoneResources) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instanceTypeZones[it.Name()] = zones
+}
+
+// ZonesForInstanceType returns the simulated zone layout for an instance type that hasn't been launched, or false
+// if this cache has no topology information for it (e.g. it predates NRT support on this instance family).
+func (c *NRTCache) ZonesForInstanceType(it cloudprovider.InstanceType) ([]ZoneResources, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	zones, ok := c.instanceTypeZones[it.Name()]
+	return zones, ok
+}
+
+// ZonesForNode returns the last-known zone layout for an existing node, and whether it's still considered fresh.
+// A node whose zones are dirty must be resynced from its NRT CR before the scheduler reuses it for in-flight
+// placement, since foreign (non-Karpenter) pods may have consumed exclusive resources out from under us.
+func (c *NRTCache) ZonesForNode(nodeName string) (zones []ZoneResources, fresh bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	t, ok := c.observed[nodeName]
+	if !ok {
+		return nil, false
+	}
+	return t.zones, !t.dirty
+}
+
+// MarkForeignPodDetected flags a node's zone allocations as dirty because a pod we didn't schedule (and therefore
+// can't account for) was observed consuming exclusive resources on it. ZonesForNode then reports the node as not
+// fresh until the next UpdateFromCR for it; nothing in this package triggers that resync.
+func (c *NRTCache) MarkForeignPodDetected(nodeName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t, ok := c.observed[nodeName]; ok {
+		t.dirty = true
+	}
+}
+
+// ObservePods reconciles the set of pods claiming exclusive NUMA resources (whole CPUs, devices, or hugepages) on
+// node against what was known from the last call. This package has no way to tell a pod Karpenter scheduled apart
+// from one it didn't, so any newly-seen exclusive-resource pod is treated as the "foreign pod" MarkForeignPodDetected
+// exists for: something consumed a zone's exclusive capacity between the last resync and now, so the cached layout
+// can no longer be trusted until it's refreshed from the node's NodeResourceTopology CR.
+func (c *NRTCache) ObservePods(nodeName string, pods []*v1.Pod) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	known := c.knownExclusivePods[nodeName]
+	current := map[types.NamespacedName]bool{}
+	foreign := false
+	for _, pod := range pods {
+		if !claimsExclusiveResources(pod) {
+			continue
+		}
+		key := client.ObjectKeyFromObject(pod)
+		current[key] = true
+		if !known[key] {
+			foreign = true
+		}
+	}
+	c.knownExclusivePods[nodeName] = current
+	if foreign {
+		if t, ok := c.observed[nodeName]; ok {
+			t.dirty = true
+		}
+	}
+}
+
+// claimsExclusiveResources reports whether pod would require single-NUMA-node admission: Guaranteed-QoS containers
+// requesting whole CPUs, or any device/hugepage resource. This mirrors scheduling.requiresSingleNUMANode closely
+// enough to flag the same class of pod; it's duplicated rather than shared because the scheduling package already
+// imports this one, so this package can't import scheduling back without a cycle.
+func claimsExclusiveResources(pod *v1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		cpu, ok := container.Resources.Requests[v1.ResourceCPU]
+		if ok && cpu.MilliValue()%1000 == 0 && cpu.Cmp(container.Resources.Limits[v1.ResourceCPU]) == 0 {
+			return true
+		}
+		for name := range container.Resources.Requests {
+			if name != v1.ResourceCPU && name != v1.ResourceMemory {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	topologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/apis/topology/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func guaranteedPod(name string, wholeCPU bool) *v1.Pod {
+	cpu := resource.MustParse("1")
+	if !wholeCPU {
+		cpu = resource.MustParse("500m")
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: cpu},
+					Limits:   v1.ResourceList{v1.ResourceCPU: cpu},
+				},
+			}},
+		},
+	}
+}
+
+func TestNRTCacheUpdateFromCR(t *testing.T) {
+	c := NewNRTCache()
+	if _, fresh := c.ZonesForNode("node-1"); fresh {
+		t.Fatalf("expected an unobserved node to not be fresh")
+	}
+	c.UpdateFromCR(&topologyv1alpha1.NodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Zones: topologyv1alpha1.ZoneList{{
+			Name: "zone-0",
+			Resources: topologyv1alpha1.ResourceInfoList{{
+				Name:        string(v1.ResourceCPU),
+				Allocatable: resource.MustParse("4"),
+			}},
+		}},
+	})
+	zones, fresh := c.ZonesForNode("node-1")
+	if !fresh {
+		t.Fatalf("expected node-1 to be fresh immediately after UpdateFromCR")
+	}
+	if len(zones) != 1 || zones[0].Name != "zone-0" {
+		t.Fatalf("expected exactly [zone-0], got %v", zones)
+	}
+}
+
+func TestNRTCacheObservePodsMarksDirtyOnNewExclusivePod(t *testing.T) {
+	c := NewNRTCache()
+	c.UpdateFromCR(&topologyv1alpha1.NodeResourceTopology{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	// a pod requesting fractional CPU doesn't claim exclusive resources, so it shouldn't dirty the cache.
+	c.ObservePods("node-1", []*v1.Pod{guaranteedPod("shared", false)})
+	if _, fresh := c.ZonesForNode("node-1"); !fresh {
+		t.Fatalf("expected a non-exclusive pod to leave node-1 fresh")
+	}
+
+	// a newly-appeared pod requesting a whole CPU under Guaranteed QoS claims an exclusive resource we hadn't
+	// accounted for, so it must dirty the cache.
+	c.ObservePods("node-1", []*v1.Pod{guaranteedPod("shared", false), guaranteedPod("exclusive", true)})
+	if _, fresh := c.ZonesForNode("node-1"); fresh {
+		t.Fatalf("expected a newly-observed exclusive-resource pod to mark node-1 dirty")
+	}
+}
+
+func TestNRTCacheObservePodsDoesNotRedirtyKnownExclusivePod(t *testing.T) {
+	c := NewNRTCache()
+	c.UpdateFromCR(&topologyv1alpha1.NodeResourceTopology{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	c.ObservePods("node-1", []*v1.Pod{guaranteedPod("exclusive", true)})
+	// re-resync clears dirty, simulating the controller re-observing the CR after the prior ObservePods dirtied it.
+	c.UpdateFromCR(&topologyv1alpha1.NodeResourceTopology{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	// observing the exact same pod again shouldn't re-dirty the cache, since it was already accounted for.
+	c.ObservePods("node-1", []*v1.Pod{guaranteedPod("exclusive", true)})
+	if _, fresh := c.ZonesForNode("node-1"); !fresh {
+		t.Fatalf("expected re-observing an already-known exclusive pod to leave node-1 fresh")
+	}
+}
+
+func TestNRTCacheAddInstanceTypeSeedsZones(t *testing.T) {
+	c := NewNRTCache()
+	it := &fakeInstanceType{name: "m5.large"}
+	if _, ok := c.ZonesForInstanceType(it); ok {
+		t.Fatalf("expected no zones for an instance type that hasn't been added")
+	}
+	zones := []ZoneResources{{Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")}}}
+	c.AddInstanceType(it, zones)
+	got, ok := c.ZonesForInstanceType(it)
+	if !ok || len(got) != 1 {
+		t.Fatalf("expected the seeded zone to be returned, got %v, %v", got, ok)
+	}
+}
+
+type fakeInstanceType struct {
+	name string
+}
+
+func (f *fakeInstanceType) Name() string               { return f.name }
+func (f *fakeInstanceType) Price() float64             { return 0 }
+func (f *fakeInstanceType) Resources() v1.ResourceList { return v1.ResourceList{} }
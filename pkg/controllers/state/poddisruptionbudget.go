@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/logging"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const pdbControllerName = "poddisruptionbudget-state"
+
+// PodDisruptionBudgetController reconciles PodDisruptionBudgets for the purpose of keeping Cluster's cached view
+// of them up to date, the same way NodeController does for nodes. Preemption consults Cluster.PodDisruptionBudgets
+// rather than listing PodDisruptionBudgets from the API server on every Compute call.
+type PodDisruptionBudgetController struct {
+	kubeClient client.Client
+	cluster    *Cluster
+}
+
+// NewPodDisruptionBudgetController constructs a controller instance.
+func NewPodDisruptionBudgetController(kubeClient client.Client, cluster *Cluster) *PodDisruptionBudgetController {
+	return &PodDisruptionBudgetController{
+		kubeClient: kubeClient,
+		cluster:    cluster,
+	}
+}
+
+func (c *PodDisruptionBudgetController) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).Named(pdbControllerName).With("poddisruptionbudget", req.NamespacedName))
+	pdb := &policyv1.PodDisruptionBudget{}
+	if err := c.kubeClient.Get(ctx, req.NamespacedName, pdb); err != nil {
+		if errors.IsNotFound(err) {
+			c.cluster.deletePDB(req.NamespacedName.String())
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	c.cluster.updatePDB(pdb)
+	return reconcile.Result{Requeue: true, RequeueAfter: stateRetryPeriod}, nil
+}
+
+func (c *PodDisruptionBudgetController) Register(ctx context.Context, m manager.Manager) error {
+	return controllerruntime.
+		NewControllerManagedBy(m).
+		Named(pdbControllerName).
+		For(&policyv1.PodDisruptionBudget{}).
+		Complete(c)
+}
@@ -0,0 +1,35 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events records the Karpenter-specific Kubernetes events emitted while scheduling and rebalancing pods.
+package events
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// Recorder is the set of Karpenter-specific events the scheduling and state packages emit in the course of
+// placing, failing to place, preempting for, or rebalancing pods.
+type Recorder interface {
+	// PodShouldSchedule records that pod is expected to schedule onto node once it's launched (or, if node already
+	// exists, onto its existing capacity).
+	PodShouldSchedule(pod *v1.Pod, node *v1.Node)
+	// PodFailedToSchedule records that pod could not be placed anywhere, with the error explaining why.
+	PodFailedToSchedule(pod *v1.Pod, err error)
+	// PodShouldPreempt records that placing pod onto node requires evicting the given lower-priority pods first.
+	PodShouldPreempt(pod *v1.Pod, node *v1.Node, evictions []*v1.Pod)
+	// PodShouldEvict records that pod should be evicted from its current node to reduce skew, to be rescheduled
+	// onto target instead.
+	PodShouldEvict(pod *v1.Pod, from *v1.Node, to *v1.Node)
+}
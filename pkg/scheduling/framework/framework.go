@@ -0,0 +1,172 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework defines the pluggable Filter/Score plugin chain that Scheduler.add runs pods through when
+// selecting instance types for a new node, modeled loosely on kube-scheduler's own scheduling framework. Built-in behavior
+// (HostPort conflicts, topology spread, daemon overhead, price-sorted selection) is expressed as plugins in the
+// pkg/scheduling/framework/plugins package so it composes with third-party plugins the same way.
+package framework
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/scheduling"
+)
+
+// FilterPlugin narrows the instance types a pod could be placed onto a candidate node template with. A plugin
+// rejects a pod either with an empty result and a nil error, or with an error that explains the rejection (the
+// built-in HostPort and Topology filters do the latter); the scheduler treats both as the node template not fitting.
+type FilterPlugin interface {
+	Name() string
+	Filter(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error)
+}
+
+// ScorePlugin ranks how good a fit an already-Filtered instance type is for a pod. Higher is better. Plugins are expected
+// to return values on a comparable scale (the built-ins normalize to 0-100) since scores from multiple plugins are
+// combined by weight.
+type ScorePlugin interface {
+	Name() string
+	Score(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceType cloudprovider.InstanceType) int64
+}
+
+// PluginWeight pairs a plugin name (as registered with a Registry) with the weight its score is multiplied by
+// before being summed with the other plugins in a Profile.
+type PluginWeight struct {
+	Name   string
+	Weight int64
+}
+
+// Profile is a named, ordered chain of filter and score plugins, referenced by Provisioner.Spec.SchedulerProfile.
+// Filters run in order, each narrowing the surviving instance types; scores run against whatever survives
+// filtering and are combined by weight to pick the final instance type.
+type Profile struct {
+	Name    string
+	Filters []string
+	Scores  []PluginWeight
+}
+
+// DefaultProfile reproduces Karpenter's historical, non-pluggable behavior: HostPort conflict, topology, and
+// daemon overhead filtering, followed by price-sorted selection. Provisioners that don't reference a profile use
+// this one, so enabling the framework doesn't change behavior for existing users.
+var DefaultProfile = Profile{
+	Name:    "default",
+	Filters: []string{"HostPort", "Topology", "DaemonOverhead"},
+	Scores:  []PluginWeight{{Name: "Price", Weight: 1}},
+}
+
+// namedProfiles holds every Profile that's been registered under a name, so a Provisioner can reference one by
+// its v1alpha5.SchedulerProfileName instead of needing the scheduler to know about it at compile time.
+var namedProfiles = map[string]Profile{
+	DefaultProfile.Name: DefaultProfile,
+}
+
+// RegisterProfile makes a Profile resolvable by name via ProfileByName. Operators call this at startup (typically
+// alongside RegisterFilter/RegisterScore for the plugins it references) to make a custom profile available for
+// provisioners to opt into.
+func RegisterProfile(p Profile) {
+	namedProfiles[p.Name] = p
+}
+
+// ProfileByName resolves a profile name to its Profile, falling back to DefaultProfile if name is empty or
+// unknown. An unknown non-empty name is not reported as an error, here or later: the caller gets DefaultProfile,
+// exactly as for an empty name.
+func ProfileByName(name string) Profile {
+	if name == "" {
+		return DefaultProfile
+	}
+	if p, ok := namedProfiles[name]; ok {
+		return p
+	}
+	return DefaultProfile
+}
+
+// Registry resolves plugin names (as referenced by a Profile) to their implementations. Plugins are added with
+// RegisterFilter and RegisterScore; plugins.RegisterBuiltins adds the built-in ones.
+type Registry struct {
+	filters map[string]FilterPlugin
+	scores  map[string]ScorePlugin
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		filters: map[string]FilterPlugin{},
+		scores:  map[string]ScorePlugin{},
+	}
+}
+
+// RegisterFilter adds a FilterPlugin under its own Name(), overwriting any plugin previously registered with that
+// name. This mirrors how later registrations win in kube-scheduler's own plugin registry.
+func (r *Registry) RegisterFilter(p FilterPlugin) {
+	r.filters[p.Name()] = p
+}
+
+// RegisterScore adds a ScorePlugin under its own Name().
+func (r *Registry) RegisterScore(p ScorePlugin) {
+	r.scores[p.Name()] = p
+}
+
+// RunFilters runs profile's filter chain against instanceTypes in order, each stage narrowing the surviving set,
+// and returns whatever instance types survive every filter. It returns an error if a filter rejects the pod with
+// one, or if profile references a plugin name this registry doesn't know about.
+func (r *Registry) RunFilters(profile Profile, pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	surviving := instanceTypes
+	for _, name := range profile.Filters {
+		plugin, ok := r.filters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter plugin %q", name)
+		}
+		var err error
+		surviving, err = plugin.Filter(pod, nodeTemplate, surviving)
+		if err != nil {
+			return nil, fmt.Errorf("filter plugin %q: %w", name, err)
+		}
+		if len(surviving) == 0 {
+			return nil, nil
+		}
+	}
+	return surviving, nil
+}
+
+// RunScore scores every surviving instance type against profile's score plugins and returns them ordered best
+// (highest weighted total) first.
+func (r *Registry) RunScore(profile Profile, pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	type scored struct {
+		it    cloudprovider.InstanceType
+		total int64
+	}
+	results := make([]scored, 0, len(instanceTypes))
+	for _, it := range instanceTypes {
+		var total int64
+		for _, pw := range profile.Scores {
+			plugin, ok := r.scores[pw.Name]
+			if !ok {
+				return nil, fmt.Errorf("unknown score plugin %q", pw.Name)
+			}
+			total += plugin.Score(pod, nodeTemplate, it) * pw.Weight
+		}
+		results = append(results, scored{it: it, total: total})
+	}
+	// SliceStable so instance types that tie on score keep their incoming (price-sorted) relative order
+	sort.SliceStable(results, func(i, j int) bool { return results[i].total > results[j].total })
+	out := make([]cloudprovider.InstanceType, len(results))
+	for i, r := range results {
+		out[i] = r.it
+	}
+	return out, nil
+}
@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/scheduling"
+)
+
+type fakeInstanceType struct {
+	name  string
+	price float64
+}
+
+func (f *fakeInstanceType) Name() string               { return f.name }
+func (f *fakeInstanceType) Price() float64             { return f.price }
+func (f *fakeInstanceType) Resources() v1.ResourceList { return v1.ResourceList{} }
+
+// rejectByName filters out any instance type whose name is in rejected, for exercising RunFilters' narrowing and
+// short-circuit behavior without depending on a real plugin's logic.
+type rejectByName struct {
+	name     string
+	rejected map[string]bool
+}
+
+func (p rejectByName) Name() string { return p.name }
+
+func (p rejectByName) Filter(_ *v1.Pod, _ *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	var surviving []cloudprovider.InstanceType
+	for _, it := range instanceTypes {
+		if !p.rejected[it.Name()] {
+			surviving = append(surviving, it)
+		}
+	}
+	return surviving, nil
+}
+
+// scoreByName scores each instance type by a fixed lookup table, for exercising RunScore's weighting/ordering.
+type scoreByName struct {
+	name   string
+	scores map[string]int64
+}
+
+func (p scoreByName) Name() string { return p.name }
+
+func (p scoreByName) Score(_ *v1.Pod, _ *scheduling.NodeTemplate, instanceType cloudprovider.InstanceType) int64 {
+	return p.scores[instanceType.Name()]
+}
+
+func TestRunFiltersNarrowsAcrossStages(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterFilter(rejectByName{name: "RejectA", rejected: map[string]bool{"a": true}})
+	r.RegisterFilter(rejectByName{name: "RejectB", rejected: map[string]bool{"b": true}})
+	profile := Profile{Filters: []string{"RejectA", "RejectB"}}
+	instanceTypes := []cloudprovider.InstanceType{
+		&fakeInstanceType{name: "a"}, &fakeInstanceType{name: "b"}, &fakeInstanceType{name: "c"},
+	}
+
+	surviving, err := r.RunFilters(profile, nil, nil, instanceTypes)
+	if err != nil {
+		t.Fatalf("RunFilters() returned error: %v", err)
+	}
+	if len(surviving) != 1 || surviving[0].Name() != "c" {
+		t.Fatalf("expected only [c] to survive both filters, got %v", names(surviving))
+	}
+}
+
+func TestRunFiltersStopsOnceEmpty(t *testing.T) {
+	r := NewRegistry()
+	calls := 0
+	r.RegisterFilter(rejectByName{name: "RejectAll", rejected: map[string]bool{"a": true}})
+	r.RegisterFilter(countingFilter{name: "Counter", calls: &calls})
+	profile := Profile{Filters: []string{"RejectAll", "Counter"}}
+	instanceTypes := []cloudprovider.InstanceType{&fakeInstanceType{name: "a"}}
+
+	surviving, err := r.RunFilters(profile, nil, nil, instanceTypes)
+	if err != nil {
+		t.Fatalf("RunFilters() returned error: %v", err)
+	}
+	if len(surviving) != 0 {
+		t.Fatalf("expected no instance types to survive, got %v", names(surviving))
+	}
+	if calls != 0 {
+		t.Fatalf("expected the later filter to be skipped once nothing survived, but it ran %d time(s)", calls)
+	}
+}
+
+func TestRunFiltersUnknownPluginNameErrors(t *testing.T) {
+	r := NewRegistry()
+	profile := Profile{Filters: []string{"DoesNotExist"}}
+
+	if _, err := r.RunFilters(profile, nil, nil, nil); err == nil {
+		t.Fatalf("expected RunFilters() to error on an unregistered filter name")
+	}
+}
+
+func TestRunScoreOrdersByWeightedTotal(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterScore(scoreByName{name: "A", scores: map[string]int64{"low": 1, "high": 10}})
+	r.RegisterScore(scoreByName{name: "B", scores: map[string]int64{"low": 1, "high": 1}})
+	profile := Profile{Scores: []PluginWeight{{Name: "A", Weight: 2}, {Name: "B", Weight: 1}}}
+	instanceTypes := []cloudprovider.InstanceType{&fakeInstanceType{name: "low"}, &fakeInstanceType{name: "high"}}
+
+	ordered, err := r.RunScore(profile, nil, nil, instanceTypes)
+	if err != nil {
+		t.Fatalf("RunScore() returned error: %v", err)
+	}
+	if len(ordered) != 2 || ordered[0].Name() != "high" || ordered[1].Name() != "low" {
+		t.Fatalf("expected [high, low] ordered by weighted score, got %v", names(ordered))
+	}
+}
+
+func TestDefaultProfileIncludesDaemonOverheadFilter(t *testing.T) {
+	found := false
+	for _, name := range DefaultProfile.Filters {
+		if name == "DaemonOverhead" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected DefaultProfile.Filters to include DaemonOverhead, got %v", DefaultProfile.Filters)
+	}
+}
+
+type countingFilter struct {
+	name  string
+	calls *int
+}
+
+func (f countingFilter) Name() string { return f.name }
+
+func (f countingFilter) Filter(_ *v1.Pod, _ *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	*f.calls++
+	return instanceTypes, nil
+}
+
+func names(instanceTypes []cloudprovider.InstanceType) []string {
+	out := make([]string, len(instanceTypes))
+	for i, it := range instanceTypes {
+		out[i] = it.Name()
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugins holds the built-in Filter and Score plugins that reproduce Karpenter's historical, non-pluggable
+// scheduling behavior. NewScheduler calls RegisterBuiltins on the registry it builds, so Provisioners that don't
+// reference a framework.Profile keep behaving exactly as they did before the framework existed.
+package plugins
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/scheduling"
+	"github.com/aws/karpenter/pkg/scheduling/framework"
+)
+
+// RegisterBuiltins registers every built-in plugin into r.
+func RegisterBuiltins(r *framework.Registry) {
+	r.RegisterFilter(hostPortFilter{})
+	r.RegisterFilter(topologyFilter{})
+	r.RegisterFilter(daemonOverheadFilter{})
+	r.RegisterScore(priceScore{})
+}
+
+// daemonOverheadFilter rejects instance types that are too small to fit pod once the node template's daemon
+// overhead (the resources every node of this template reserves for DaemonSet pods) is taken into account.
+type daemonOverheadFilter struct{}
+
+func (daemonOverheadFilter) Name() string { return "DaemonOverhead" }
+
+func (daemonOverheadFilter) Filter(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	if len(nodeTemplate.DaemonOverhead) == 0 {
+		return instanceTypes, nil
+	}
+	var viable []cloudprovider.InstanceType
+	for _, it := range instanceTypes {
+		if fitsWithOverhead(pod, it, nodeTemplate.DaemonOverhead) {
+			viable = append(viable, it)
+		}
+	}
+	return viable, nil
+}
+
+func fitsWithOverhead(pod *v1.Pod, it cloudprovider.InstanceType, overhead v1.ResourceList) bool {
+	available := it.Resources()
+	for name, qty := range overhead {
+		if avail, ok := available[name]; ok {
+			avail.Sub(qty)
+			available[name] = avail
+		}
+	}
+	for _, c := range pod.Spec.Containers {
+		for name, qty := range c.Resources.Requests {
+			avail, ok := available[name]
+			if !ok || avail.Cmp(qty) < 0 {
+				return false
+			}
+			avail.Sub(qty)
+			available[name] = avail
+		}
+	}
+	return true
+}
+
+// hostPortFilter rejects instance types for a pod whose requested HostPorts can't be reserved without conflicting
+// with another pod already committed to the same node template. This is the same conflict check Node.Add
+// previously ran inline; as a plugin it now also applies to third-party profiles that include it.
+type hostPortFilter struct{}
+
+func (hostPortFilter) Name() string { return "HostPort" }
+
+func (hostPortFilter) Filter(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	// HostPort conflicts are a property of the node template's already-committed pods, not of any particular
+	// instance type, so this filter either passes every instance type through or rejects the pod outright. It
+	// probes a copy rather than reserving against nodeTemplate.HostPortUsage directly: Filter is a read-only check
+	// that may run against the same node template for several pods before any of them are actually committed, and
+	// mutating it here would reserve pod's ports even if it's never placed on this node template. Node.Add is the
+	// one that commits the reservation once a pod is actually scheduled here.
+	if nodeTemplate.HostPortUsage == nil {
+		return instanceTypes, nil
+	}
+	if err := nodeTemplate.HostPortUsage.Copy().Add(pod); err != nil {
+		return nil, err
+	}
+	return instanceTypes, nil
+}
+
+// topologyFilter rejects instance types that would place pod in a zone, hostname, or other topology domain that
+// already violates the pod's topology spread constraints, delegating the actual computation to the existing
+// scheduling.Topology so this plugin doesn't duplicate that logic.
+type topologyFilter struct{}
+
+func (topologyFilter) Name() string { return "Topology" }
+
+func (topologyFilter) Filter(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceTypes []cloudprovider.InstanceType) ([]cloudprovider.InstanceType, error) {
+	if nodeTemplate.Topology == nil {
+		return instanceTypes, nil
+	}
+	if err := nodeTemplate.Topology.Compatible(pod); err != nil {
+		return nil, err
+	}
+	return instanceTypes, nil
+}
+
+// priceScore ranks cheaper instance types higher, reproducing the price-sorted selection Node.Add previously did
+// unconditionally. Scores are normalized to 0-100 so they combine predictably with other score plugins' weights.
+type priceScore struct{}
+
+func (priceScore) Name() string { return "Price" }
+
+func (priceScore) Score(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceType cloudprovider.InstanceType) int64 {
+	// a lower price should score higher; inverting via a constant ceiling keeps the score non-negative for any
+	// realistic per-hour instance price without needing the full candidate set to normalize against.
+	const priceCeiling = 100.0
+	price := instanceType.Price()
+	if price <= 0 {
+		return int64(priceCeiling)
+	}
+	score := priceCeiling - price
+	if score < 0 {
+		return 0
+	}
+	return int64(score)
+}
@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter/pkg/cloudprovider"
+	"github.com/aws/karpenter/pkg/scheduling"
+	"github.com/aws/karpenter/pkg/scheduling/framework"
+)
+
+// SpotInterruptionRiskScore ranks instance types by their reported spot interruption frequency, preferring the
+// lower-risk pool. It's not registered by RegisterBuiltins, so the registry NewScheduler builds does not resolve it;
+// a Registry resolves it only after it has been added with RegisterScore.
+type SpotInterruptionRiskScore struct {
+	// InterruptionRisk maps instance type name to an interruption frequency in [0, 100], typically sourced from a
+	// cloud provider's spot advisor data. Instance types with no entry are treated as risk-free.
+	InterruptionRisk map[string]int64
+}
+
+func (SpotInterruptionRiskScore) Name() string { return "SpotInterruptionRisk" }
+
+func (s SpotInterruptionRiskScore) Score(pod *v1.Pod, nodeTemplate *scheduling.NodeTemplate, instanceType cloudprovider.InstanceType) int64 {
+	risk := s.InterruptionRisk[instanceType.Name()]
+	if risk < 0 {
+		risk = 0
+	}
+	if risk > 100 {
+		risk = 100
+	}
+	return 100 - risk
+}
+
+var _ framework.ScorePlugin = SpotInterruptionRiskScore{}
@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resources holds small, dependency-free helpers for working with v1.ResourceList that are needed in
+// more than one package (scheduling, preemption, consolidation) and don't belong to any one of them.
+package resources
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Cmp compares two resource quantities, returning -1, 0, or 1 as resource.Quantity.Cmp does.
+func Cmp(lhs, rhs resource.Quantity) int {
+	return lhs.Cmp(rhs)
+}
+
+// Subtract returns lhs - rhs for every resource present in lhs. A resource missing from rhs is treated as zero; a
+// resource present only in rhs is ignored, so the result contains exactly the keys of lhs.
+func Subtract(lhs, rhs v1.ResourceList) v1.ResourceList {
+	result := v1.ResourceList{}
+	for k, v := range lhs {
+		cp := v.DeepCopy()
+		if other, ok := rhs[k]; ok {
+			cp.Sub(other)
+		}
+		result[k] = cp
+	}
+	return result
+}
+
+// Merge returns the sum of lhs and rhs for every resource present in either list.
+func Merge(lhs, rhs v1.ResourceList) v1.ResourceList {
+	result := v1.ResourceList{}
+	for k, v := range lhs {
+		cp := v.DeepCopy()
+		result[k] = cp
+	}
+	for k, v := range rhs {
+		cp := v.DeepCopy()
+		if existing, ok := result[k]; ok {
+			cp.Add(existing)
+		}
+		result[k] = cp
+	}
+	return result
+}
+
+// MaxResources returns, for each resource name present in any of resourceLists, the largest quantity seen across
+// all of them. It's used to pessimistically account for the largest instance type that could be launched when the
+// actual instance type selection hasn't happened yet.
+func MaxResources(resourceLists ...v1.ResourceList) v1.ResourceList {
+	result := v1.ResourceList{}
+	for _, rl := range resourceLists {
+		for k, v := range rl {
+			if existing, ok := result[k]; !ok || v.Cmp(existing) > 0 {
+				result[k] = v.DeepCopy()
+			}
+		}
+	}
+	return result
+}
+
+// RequestsForPod returns the effective resource requests of pod: the sum of its containers' requests. Init
+// containers aren't included since they don't run concurrently with the pod's regular containers.
+func RequestsForPod(pod *v1.Pod) v1.ResourceList {
+	result := v1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, qty := range c.Resources.Requests {
+			cp := qty.DeepCopy()
+			if existing, ok := result[name]; ok {
+				cp.Add(existing)
+			}
+			result[name] = cp
+		}
+	}
+	return result
+}